@@ -0,0 +1,118 @@
+package api2go
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// assignAttribute sets field from a decoded JSON value v, coercing between
+// the limited set of types encoding/json produces (string, float64, bool,
+// map, slice, nil) and the struct field's actual type. fieldName is only
+// used for error reporting.
+//
+// Pointer fields are allocated on demand (or left nil for a nil v) and the
+// pointee is assigned recursively, so e.g. *string, *int64 and *time.Time
+// all work. Sized/unsigned ints and float32 are converted from the
+// JSON-decoded float64 with overflow checks, and a numeric value assigned
+// to a string field is formatted rather than rejected.
+func assignAttribute(field reflect.Value, v interface{}, fieldName string) error {
+	if field.Kind() == reflect.Ptr {
+		if v == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		ptr := reflect.New(field.Type().Elem())
+		if err := assignAttribute(ptr.Elem(), v, fieldName); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := v.(float64)
+		if !ok {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		i := int64(f)
+		if field.OverflowInt(i) {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		field.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := v.(float64)
+		if !ok || f < 0 {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		u := uint64(f)
+		if field.OverflowUint(u) {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		field.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := v.(float64)
+		if !ok {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		if field.OverflowFloat(f) {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		field.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		switch val := v.(type) {
+		case string:
+			field.SetString(val)
+		case float64:
+			field.SetString(strconv.FormatFloat(val, 'f', -1, 64))
+		default:
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		return nil
+
+	default:
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() || !rv.Type().AssignableTo(field.Type()) {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: v, Type: field.Type()}
+		}
+		field.Set(rv)
+		return nil
+	}
+}
+
+// assignID sets an ID field (or *ID field) from the string id carried by a
+// JSON API resource object.
+func assignID(field reflect.Value, fieldName, idStr string) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := assignID(ptr.Elem(), fieldName, idStr); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(idStr)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return err
+		}
+		if field.OverflowInt(intID) {
+			return &ErrUnsupportedPtrType{Field: fieldName, Value: idStr, Type: field.Type()}
+		}
+		field.SetInt(intID)
+		return nil
+	default:
+		return &ErrUnsupportedPtrType{Field: fieldName, Value: idStr, Type: field.Type()}
+	}
+}
@@ -0,0 +1,82 @@
+package api2go
+
+import "testing"
+
+// widgetAttrs exercises chunk0-4's numeric/pointer coercion in
+// assignAttribute: sized ints with overflow checking, unsigned ints
+// rejecting negative input, and pointer fields allocated on demand.
+type widgetAttrs struct {
+	ID      string `api2go:"primary,widgets"`
+	Count   int8   `api2go:"attr,count"`
+	Amount  uint8  `api2go:"attr,amount"`
+	Version *int64 `api2go:"attr,version"`
+}
+
+func TestAssignAttributeIntOverflowReturnsError(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "widgets",
+			"id":         "1",
+			"attributes": map[string]interface{}{"count": float64(1000)}, // overflows int8
+		},
+	}
+
+	var w widgetAttrs
+	if err := UnmarshalOne(ctx, &w); err == nil {
+		t.Fatal("expected an overflow error assigning 1000 into an int8 field, got nil")
+	}
+}
+
+func TestAssignAttributeNegativeIntoUnsignedReturnsError(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "widgets",
+			"id":         "1",
+			"attributes": map[string]interface{}{"amount": float64(-1)},
+		},
+	}
+
+	var w widgetAttrs
+	if err := UnmarshalOne(ctx, &w); err == nil {
+		t.Fatal("expected an error assigning -1 into a uint8 field, got nil")
+	}
+}
+
+func TestAssignAttributeAllocatesPointerField(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "widgets",
+			"id":         "1",
+			"attributes": map[string]interface{}{"version": float64(42)},
+		},
+	}
+
+	var w widgetAttrs
+	if err := UnmarshalOne(ctx, &w); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if w.Version == nil {
+		t.Fatal("Version = nil, want a populated *int64")
+	}
+	if *w.Version != 42 {
+		t.Fatalf("*Version = %d, want 42", *w.Version)
+	}
+}
+
+func TestAssignAttributeNilLeavesPointerFieldNil(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "widgets",
+			"id":         "1",
+			"attributes": map[string]interface{}{"version": nil},
+		},
+	}
+
+	var w widgetAttrs
+	if err := UnmarshalOne(ctx, &w); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if w.Version != nil {
+		t.Fatalf("Version = %v, want nil", w.Version)
+	}
+}
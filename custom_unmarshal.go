@@ -0,0 +1,40 @@
+package api2go
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// UnmarshalerJSONAPI lets a type decode its own attribute value, for data
+// that the reflect-based path can't represent directly (money amounts,
+// enums, GeoJSON blobs, big.Int, ...). raw is the value as decoded by
+// encoding/json (string, float64, bool, map[string]interface{},
+// []interface{} or nil).
+type UnmarshalerJSONAPI interface {
+	UnmarshalJSONAPI(raw interface{}) error
+}
+
+// tryCustomUnmarshal gives field a chance to decode v itself, checking
+// UnmarshalerJSONAPI first and falling back to encoding/json.Unmarshaler
+// (re-encoding v since it was already decoded out of the outer document).
+func tryCustomUnmarshal(field reflect.Value, v interface{}) (handled bool, err error) {
+	if !field.CanAddr() {
+		return false, nil
+	}
+
+	addr := field.Addr().Interface()
+
+	if u, ok := addr.(UnmarshalerJSONAPI); ok {
+		return true, u.UnmarshalJSONAPI(v)
+	}
+
+	if u, ok := addr.(json.Unmarshaler); ok {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return true, err
+		}
+		return true, u.UnmarshalJSON(raw)
+	}
+
+	return false, nil
+}
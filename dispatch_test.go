@@ -0,0 +1,81 @@
+package api2go
+
+import "testing"
+
+// dispatchWidget is a plain struct used to exercise Unmarshal's
+// struct-vs-slice dispatch added in chunk0-5.
+type dispatchWidget struct {
+	ID   string `api2go:"primary,dispatchWidgets"`
+	Name string `api2go:"attr,name"`
+}
+
+func TestUnmarshalDispatchesToUnmarshalOneForStructPointer(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "dispatchWidgets",
+			"id":         "1",
+			"attributes": map[string]interface{}{"name": "gizmo"},
+		},
+	}
+
+	var w dispatchWidget
+	if err := Unmarshal(ctx, &w); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if w.Name != "gizmo" {
+		t.Fatalf("Name = %q, want %q", w.Name, "gizmo")
+	}
+}
+
+func TestUnmarshalDispatchesToUnmarshalManyForSlicePointer(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": []interface{}{
+			map[string]interface{}{
+				"type":       "dispatchWidgets",
+				"id":         "1",
+				"attributes": map[string]interface{}{"name": "gizmo"},
+			},
+		},
+	}
+
+	var widgets []dispatchWidget
+	if err := Unmarshal(ctx, &widgets); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(widgets) != 1 || widgets[0].Name != "gizmo" {
+		t.Fatalf("widgets = %+v, want a single gizmo", widgets)
+	}
+}
+
+func TestUnmarshalRejectsNonPointerInsteadOfPanicking(t *testing.T) {
+	var w dispatchWidget
+	err := Unmarshal(unmarshalContext{}, w) // not a pointer
+	if err == nil {
+		t.Fatal("expected an error passing a non-pointer to Unmarshal, got nil")
+	}
+}
+
+func TestUnmarshalRejectsNilPointerInsteadOfPanicking(t *testing.T) {
+	var w *dispatchWidget
+	err := Unmarshal(unmarshalContext{}, w) // nil pointer
+	if err == nil {
+		t.Fatal("expected an error passing a nil pointer to Unmarshal, got nil")
+	}
+}
+
+func TestUnmarshalRejectsPointerToNonStructNonSlice(t *testing.T) {
+	var n int
+	err := Unmarshal(unmarshalContext{}, &n)
+	if err == nil {
+		t.Fatal("expected an error passing *int to Unmarshal, got nil")
+	}
+}
+
+func TestUnmarshalOneErrorsWhenNoResourcesPresent(t *testing.T) {
+	ctx := unmarshalContext{"data": []interface{}{}}
+
+	var w dispatchWidget
+	if err := UnmarshalOne(ctx, &w); err == nil {
+		t.Fatal("expected an error when the document contains no resources, got nil")
+	}
+}
@@ -0,0 +1,23 @@
+package api2go
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidISO8601 is returned when an attribute tagged `api2go:"attr,...,iso8601"`
+// receives a JSON number instead of an RFC 3339 encoded string.
+var ErrInvalidISO8601 = errors.New("api2go: expected an ISO 8601 string, got a number")
+
+// ErrUnsupportedPtrType is returned by assignAttribute when a decoded JSON
+// value cannot be coerced into the target struct field's type.
+type ErrUnsupportedPtrType struct {
+	Field string
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e *ErrUnsupportedPtrType) Error() string {
+	return fmt.Sprintf("api2go: cannot assign %#v to field %q of type %s", e.Value, e.Field, e.Type)
+}
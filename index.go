@@ -0,0 +1,33 @@
+package api2go
+
+import (
+	"errors"
+	"reflect"
+)
+
+// buildIDIndex walks sliceVal once and returns a map from ID string to
+// slice index, so unmarshalInto/unmarshalV1Into can merge incoming
+// resources against an existing slice in O(1) per lookup instead of
+// rescanning the whole slice for every incoming resource.
+func buildIDIndex(fm *fieldMap, structType reflect.Type, sliceVal reflect.Value) (map[string]int, error) {
+	idx := make(map[string]int, sliceVal.Len())
+	for i := 0; i < sliceVal.Len(); i++ {
+		idField, err := fm.idFieldOf(structType, sliceVal.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		id, err := toID(idField)
+		if err != nil {
+			return nil, errors.New("invalid type for ID field")
+		}
+		if id == "" {
+			continue
+		}
+		// Keep the first occurrence, matching the original linear scan's
+		// behavior of matching (and stopping at) the earliest element.
+		if _, exists := idx[id]; !exists {
+			idx[id] = i
+		}
+	}
+	return idx, nil
+}
@@ -0,0 +1,237 @@
+package api2go
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// UnmarshalStream reads a JSON API document from r into values (a pointer
+// to a slice of structs) using a json.Decoder, decoding one resource
+// object at a time instead of first building the whole document as a
+// map[string]interface{} tree. This keeps memory proportional to a single
+// resource rather than the full document, which matters for bulk imports
+// of large collections.
+//
+// Relationships are resolved against "included" resources the decoder has
+// already seen; an "included" array that appears after "data" in the
+// document will still sideload correctly for any resource read after it,
+// but a resource read before its "included" counterpart falls back to a
+// bare id the same way an un-sideloaded reference does.
+func UnmarshalStream(r io.Reader, values interface{}) error {
+	ptrVal := reflect.ValueOf(values)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
+		return errors.New("api2go: UnmarshalStream requires a non-nil pointer to a slice of structs")
+	}
+	sliceType := ptrVal.Type().Elem()
+	if sliceType.Kind() != reflect.Slice {
+		return errors.New("api2go: UnmarshalStream requires a pointer to a slice of structs")
+	}
+	structType := sliceType.Elem()
+	if structType.Kind() != reflect.Struct {
+		return errors.New("api2go: UnmarshalStream requires a pointer to a slice of structs")
+	}
+
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	fm := buildFieldMap(structType)
+	rootName := fm.rootNameFor(structType)
+	sliceVal := ptrVal.Elem()
+	included := map[includedKey]map[string]interface{}{}
+	var idx map[string]int
+	foundRoot := false
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return errors.New("api2go: expected an object key")
+		}
+
+		switch key {
+		case "data":
+			foundRoot = true
+			if idx == nil {
+				idx, err = buildIDIndex(fm, structType, sliceVal)
+				if err != nil {
+					return err
+				}
+			}
+			if err := streamResources(dec, structType, &sliceVal, fm, true, included, idx); err != nil {
+				return err
+			}
+		case rootName:
+			foundRoot = true
+			if idx == nil {
+				idx, err = buildIDIndex(fm, structType, sliceVal)
+				if err != nil {
+					return err
+				}
+			}
+			if err := streamResources(dec, structType, &sliceVal, fm, false, included, idx); err != nil {
+				return err
+			}
+		case "included":
+			var includedList []map[string]interface{}
+			if err := dec.Decode(&includedList); err != nil {
+				return err
+			}
+			for _, resource := range includedList {
+				resourceType, id, err := typeAndID(resource)
+				if err != nil {
+					return err
+				}
+				included[includedKey{Type: resourceType, ID: id}] = resource
+			}
+		default:
+			// meta, links, jsonapi, etc. - small, decode and discard.
+			var discard interface{}
+			if err := dec.Decode(&discard); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+	if !foundRoot {
+		return errors.New("api2go: expected root document to include a 'data' or '" + rootName + "' key but it didn't")
+	}
+
+	ptrVal.Elem().Set(sliceVal)
+	return nil
+}
+
+// streamResources decodes a "data" or legacy root array (or, for v1.0, a
+// single resource object) one element at a time and merges each into
+// sliceVal.
+func streamResources(dec *json.Decoder, structType reflect.Type, sliceVal *reflect.Value, fm *fieldMap, isV1 bool, included map[includedKey]map[string]interface{}, idx map[string]int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		for dec.More() {
+			var resource map[string]interface{}
+			if err := dec.Decode(&resource); err != nil {
+				return err
+			}
+			if err := streamApplyResource(structType, sliceVal, fm, isV1, resource, included, idx); err != nil {
+				return err
+			}
+		}
+		return expectDelim(dec, ']')
+
+	case json.Delim('{'):
+		if !isV1 {
+			return errors.New("api2go: expected an array of resource objects")
+		}
+		resource, err := decodeRestOfObject(dec)
+		if err != nil {
+			return err
+		}
+		return streamApplyResource(structType, sliceVal, fm, isV1, resource, included, idx)
+
+	default:
+		return errors.New("api2go: expected an array or object")
+	}
+}
+
+// streamApplyResource merges a single decoded resource object into
+// sliceVal, reusing the same per-resource logic as the in-memory path.
+func streamApplyResource(structType reflect.Type, sliceVal *reflect.Value, fm *fieldMap, isV1 bool, resource map[string]interface{}, included map[includedKey]map[string]interface{}, idx map[string]int) error {
+	if isV1 {
+		val, isNew := findOrCreateByID(structType, sliceVal, resource, idx)
+		if err := applyResourceObject(structType, val, resource, included, fm); err != nil {
+			return err
+		}
+		if isNew {
+			*sliceVal = reflect.Append(*sliceVal, val)
+			if id, _ := resource["id"].(string); id != "" {
+				idx[id] = sliceVal.Len() - 1
+			}
+		}
+		return nil
+	}
+
+	val, isNew := findOrCreateByID(structType, sliceVal, resource, idx)
+	for k, v := range resource {
+		if k == "links" {
+			if err := applyLegacyLinks(structType, val, fm, v); err != nil {
+				return err
+			}
+			continue
+		}
+		if k == "id" {
+			idStr, ok := v.(string)
+			if !ok {
+				return errors.New("expected id to be of type string")
+			}
+			field, err := fm.idFieldOf(structType, val)
+			if err != nil {
+				return err
+			}
+			if err := assignID(field, "ID", idStr); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := applyLegacyAttribute(structType, val, fm, k, v); err != nil {
+			return err
+		}
+	}
+	if isNew {
+		*sliceVal = reflect.Append(*sliceVal, val)
+		if id, _ := resource["id"].(string); id != "" {
+			idx[id] = sliceVal.Len() - 1
+		}
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != want {
+		return errors.New("api2go: malformed JSON API document")
+	}
+	return nil
+}
+
+// decodeRestOfObject decodes the remaining key/value pairs of a JSON
+// object whose opening '{' token has already been consumed.
+func decodeRestOfObject(dec *json.Decoder) (map[string]interface{}, error) {
+	resource := map[string]interface{}{}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return nil, errors.New("api2go: expected an object key")
+		}
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		resource[key] = v
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
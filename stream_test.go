@@ -0,0 +1,64 @@
+package api2go
+
+import (
+	"strings"
+	"testing"
+)
+
+// streamPost exercises chunk0-7's UnmarshalStream and its buildIDIndex
+// based merge-by-id behavior.
+type streamPost struct {
+	ID    string `api2go:"primary,posts"`
+	Title string `api2go:"attr,title"`
+}
+
+func TestUnmarshalStreamDecodesResourceArray(t *testing.T) {
+	body := `{"data":[
+		{"type":"posts","id":"1","attributes":{"title":"first"}},
+		{"type":"posts","id":"2","attributes":{"title":"second"}}
+	]}`
+
+	var posts []streamPost
+	if err := UnmarshalStream(strings.NewReader(body), &posts); err != nil {
+		t.Fatalf("UnmarshalStream: %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("len(posts) = %d, want 2", len(posts))
+	}
+	if posts[0].Title != "first" || posts[1].Title != "second" {
+		t.Fatalf("posts = %+v", posts)
+	}
+}
+
+func TestUnmarshalStreamMergesIntoExistingIndexedElement(t *testing.T) {
+	body := `{"data":[{"type":"posts","id":"1","attributes":{"title":"updated"}}]}`
+
+	posts := []streamPost{{ID: "1", Title: "original"}}
+	if err := UnmarshalStream(strings.NewReader(body), &posts); err != nil {
+		t.Fatalf("UnmarshalStream: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("len(posts) = %d, want 1 (existing id 1 should be merged, not duplicated)", len(posts))
+	}
+	if posts[0].Title != "updated" {
+		t.Fatalf("Title = %q, want %q", posts[0].Title, "updated")
+	}
+}
+
+func TestUnmarshalStreamErrorsWhenRootKeyMissing(t *testing.T) {
+	body := `{"meta":{"count":0}}`
+
+	var posts []streamPost
+	err := UnmarshalStream(strings.NewReader(body), &posts)
+	if err == nil {
+		t.Fatal("expected an error for a document with no 'data' or root key, got nil")
+	}
+}
+
+func TestUnmarshalStreamRejectsNonSlicePointer(t *testing.T) {
+	var p streamPost
+	err := UnmarshalStream(strings.NewReader(`{"data":[]}`), &p)
+	if err == nil {
+		t.Fatal("expected an error passing a non-slice pointer to UnmarshalStream, got nil")
+	}
+}
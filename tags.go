@@ -0,0 +1,160 @@
+package api2go
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// api2goTag is a parsed `api2go:"kind,name[,extra]"` struct tag.
+//
+// kind is one of "primary", "attr" or "relation"; name is the JSON API
+// name used in place of the pluralize/jsonify/dejsonify conventions; extra
+// carries an optional third token (e.g. "iso8601" on an attr tag).
+type api2goTag struct {
+	Kind  string
+	Name  string
+	Extra string
+}
+
+func parseAPI2GoTag(f reflect.StructField) (api2goTag, bool) {
+	raw, ok := f.Tag.Lookup("api2go")
+	if !ok || raw == "" {
+		return api2goTag{}, false
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) < 2 {
+		return api2goTag{}, false
+	}
+	tag := api2goTag{Kind: parts[0], Name: parts[1]}
+	if len(parts) > 2 {
+		tag.Extra = parts[2]
+	}
+	return tag, true
+}
+
+// fieldMap is the result of scanning a struct type's `api2go` tags once.
+// When tagged is false no tags were found on the type and callers should
+// fall back to the pluralize/jsonify/dejsonify naming conventions.
+type fieldMap struct {
+	tagged    bool
+	rootName  string
+	primary   reflect.StructField
+	attrs     map[string]reflect.StructField
+	attrExtra map[string]string
+	relations map[string]reflect.StructField
+}
+
+// settable returns a field obtained via reflect.Value.FieldByIndex that
+// can be Set, even when the field is unexported. Tagged fields are named
+// explicitly by the struct author via `api2go` tags rather than discovered
+// by convention, so - unlike the rest of the reflect-based unmarshaller -
+// they're expected to work on unexported fields too.
+//
+// This relies on field having come from an addressable reflect.Value (one
+// obtained from a pointer, e.g. via reflect.New(...).Elem() as UnmarshalOne
+// and friends already do) - CanAddr is what makes UnsafeAddr safe to call
+// below. Every current caller (idFieldOf, attrFieldOf, relationFieldOf)
+// satisfies this because they're always handed such a value; if that ever
+// stops being true, settable degrades gracefully to returning the original
+// unsettable field rather than panicking here, but the eventual .Set() call
+// on it will then panic instead.
+func settable(field reflect.Value) reflect.Value {
+	if field.CanSet() || !field.CanAddr() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// buildFieldMap scans structType's fields for `api2go` tags.
+func buildFieldMap(structType reflect.Type) *fieldMap {
+	fm := &fieldMap{
+		attrs:     map[string]reflect.StructField{},
+		attrExtra: map[string]string{},
+		relations: map[string]reflect.StructField{},
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		tag, ok := parseAPI2GoTag(f)
+		if !ok {
+			continue
+		}
+		fm.tagged = true
+		switch tag.Kind {
+		case "primary":
+			fm.primary = f
+			fm.rootName = tag.Name
+		case "attr":
+			fm.attrs[tag.Name] = f
+			if tag.Extra != "" {
+				fm.attrExtra[tag.Name] = tag.Extra
+			}
+		case "relation":
+			fm.relations[tag.Name] = f
+		}
+	}
+
+	return fm
+}
+
+// rootNameFor returns the top-level pluralized key for structType, honoring
+// a tagged primary field's type name if present.
+func (fm *fieldMap) rootNameFor(structType reflect.Type) string {
+	if fm != nil && fm.rootName != "" {
+		return fm.rootName
+	}
+	return pluralize(jsonify(structType.Name()))
+}
+
+// idFieldOf returns the primary key field of val, using the tagged primary
+// field when present and falling back to a field literally named "ID".
+func (fm *fieldMap) idFieldOf(structType reflect.Type, val reflect.Value) (reflect.Value, error) {
+	if fm != nil && fm.tagged && fm.primary.Name != "" {
+		return settable(val.FieldByIndex(fm.primary.Index)), nil
+	}
+	field := val.FieldByName("ID")
+	if !field.IsValid() {
+		return reflect.Value{}, errors.New("expected struct " + structType.Name() + " to have field 'ID'")
+	}
+	return field, nil
+}
+
+// attrFieldOf resolves an attribute's struct field and its optional third
+// tag token (e.g. "iso8601"). When structType carries any api2go tags the
+// pluralize/dejsonify convention is ignored entirely and a missing attr tag
+// is an error.
+func (fm *fieldMap) attrFieldOf(structType reflect.Type, val reflect.Value, jsonName string) (reflect.Value, string, error) {
+	if fm != nil && fm.tagged {
+		f, ok := fm.attrs[jsonName]
+		if !ok {
+			return reflect.Value{}, "", errors.New("expected struct " + structType.Name() + " to have an attr tag for '" + jsonName + "'")
+		}
+		return settable(val.FieldByIndex(f.Index)), fm.attrExtra[jsonName], nil
+	}
+
+	fieldName := dejsonify(jsonName)
+	field := val.FieldByName(fieldName)
+	if !field.IsValid() {
+		return reflect.Value{}, "", errors.New("expected struct " + structType.Name() + " to have field " + fieldName)
+	}
+	return field, "", nil
+}
+
+// relationFieldOf resolves a relationship's struct field by its tagged
+// name. When structType carries any api2go tags, a relationship with no
+// matching "relation" tag is reported as not found rather than falling
+// back to the FooID/FooIDs naming convention.
+func (fm *fieldMap) relationFieldOf(val reflect.Value, jsonName string) (reflect.Value, bool) {
+	if fm != nil && fm.tagged {
+		f, ok := fm.relations[jsonName]
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return settable(val.FieldByIndex(f.Index)), true
+	}
+
+	field := val.FieldByName(dejsonify(jsonName))
+	return field, field.IsValid()
+}
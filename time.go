@@ -0,0 +1,61 @@
+package api2go
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// assignTimeAttribute sets field from v when field (or, for a pointer
+// field, its pointee) is a time.Time. It reports handled=false when field
+// isn't a time.Time so the caller can fall back to generic assignment.
+//
+// By default a JSON number is treated as a Unix timestamp and a JSON
+// string is parsed as RFC 3339. A struct tag of the form
+// `api2go:"attr,name,iso8601"` restricts the field to the string encoding
+// and rejects numeric input with ErrInvalidISO8601.
+func assignTimeAttribute(field reflect.Value, v interface{}, extra string) (handled bool, err error) {
+	targetType := field.Type()
+	isPtr := targetType.Kind() == reflect.Ptr
+	elemType := targetType
+	if isPtr {
+		elemType = targetType.Elem()
+	}
+	if elemType != timeType {
+		return false, nil
+	}
+
+	if v == nil {
+		if isPtr {
+			field.Set(reflect.Zero(targetType))
+		}
+		return true, nil
+	}
+
+	var t time.Time
+	switch val := v.(type) {
+	case float64:
+		if extra == "iso8601" {
+			return true, ErrInvalidISO8601
+		}
+		t = time.Unix(int64(val), 0)
+	case string:
+		t, err = time.Parse(time.RFC3339, val)
+		if err != nil {
+			return true, err
+		}
+	default:
+		return true, errors.New("expected time attribute to be a number or an RFC 3339 string")
+	}
+
+	if isPtr {
+		ptr := reflect.New(elemType)
+		ptr.Elem().Set(reflect.ValueOf(t))
+		field.Set(ptr)
+	} else {
+		field.Set(reflect.ValueOf(t))
+	}
+	return true, nil
+}
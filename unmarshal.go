@@ -9,27 +9,49 @@ import (
 
 type unmarshalContext map[string]interface{}
 
-// Unmarshal reads a JSONAPI map to a model struct
+// Unmarshal reads a JSONAPI map into values, which must be a pointer to
+// either a struct (to read a single resource) or a slice of structs (to
+// read a collection). See UnmarshalOne and UnmarshalMany if the shape of
+// values is known ahead of time.
 func Unmarshal(ctx unmarshalContext, values interface{}) error {
-	// Check that target is a *[]Model
 	ptrVal := reflect.ValueOf(values)
 	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
-		panic("You must pass a pointer to a []struct to Unmarshal()")
+		return errors.New("api2go: Unmarshal requires a non-nil pointer to a struct or a slice of structs")
 	}
-	sliceType := reflect.TypeOf(values).Elem()
-	sliceVal := ptrVal.Elem()
+
+	switch ptrVal.Elem().Kind() {
+	case reflect.Struct:
+		return UnmarshalOne(ctx, values)
+	case reflect.Slice:
+		return UnmarshalMany(ctx, values)
+	default:
+		return errors.New("api2go: Unmarshal requires a pointer to a struct or a slice of structs")
+	}
+}
+
+// UnmarshalMany reads a JSONAPI collection document into values, which
+// must be a pointer to a slice of structs.
+func UnmarshalMany(ctx unmarshalContext, values interface{}) error {
+	ptrVal := reflect.ValueOf(values)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
+		return errors.New("api2go: UnmarshalMany requires a non-nil pointer to a slice of structs")
+	}
+	sliceType := ptrVal.Type().Elem()
 	if sliceType.Kind() != reflect.Slice {
-		panic("You must pass a pointer to a []struct to Unmarshal()")
+		return errors.New("api2go: UnmarshalMany requires a pointer to a slice of structs")
 	}
 	structType := sliceType.Elem()
 	if structType.Kind() != reflect.Struct {
-		panic("You must pass a pointer to a []struct to Unmarshal()")
+		return errors.New("api2go: UnmarshalMany requires a pointer to a slice of structs")
 	}
 
+	sliceVal := ptrVal.Elem()
+
 	// Copy the value, then write into the new variable.
 	// Later Set() the actual value of the pointee.
 	val := sliceVal
-	err := unmarshalInto(ctx, structType, &val)
+	fm := buildFieldMap(structType)
+	err := unmarshalInto(ctx, structType, &val, fm)
 	if err != nil {
 		return err
 	}
@@ -37,9 +59,40 @@ func Unmarshal(ctx unmarshalContext, values interface{}) error {
 	return nil
 }
 
-func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *reflect.Value) error {
+// UnmarshalOne reads a JSONAPI single-resource document into value, which
+// must be a pointer to a struct.
+func UnmarshalOne(ctx unmarshalContext, value interface{}) error {
+	ptrVal := reflect.ValueOf(value)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
+		return errors.New("api2go: UnmarshalOne requires a non-nil pointer to a struct")
+	}
+	structType := ptrVal.Type().Elem()
+	if structType.Kind() != reflect.Struct {
+		return errors.New("api2go: UnmarshalOne requires a pointer to a struct")
+	}
+
+	sliceVal := reflect.MakeSlice(reflect.SliceOf(structType), 0, 1)
+	fm := buildFieldMap(structType)
+	if err := unmarshalInto(ctx, structType, &sliceVal, fm); err != nil {
+		return err
+	}
+	if sliceVal.Len() == 0 {
+		return errors.New("api2go: expected exactly one resource, got none")
+	}
+
+	ptrVal.Elem().Set(sliceVal.Index(0))
+	return nil
+}
+
+func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *reflect.Value, fm *fieldMap) error {
+	// JSON API v1.0 documents carry a top-level "data" key instead of the
+	// legacy pluralized-typename root key; dispatch to that code path first.
+	if _, ok := ctx["data"]; ok {
+		return unmarshalV1Into(ctx, structType, sliceVal, fm)
+	}
+
 	// Read models slice
-	rootName := pluralize(jsonify(structType.Name()))
+	rootName := fm.rootNameFor(structType)
 	var modelsInterface interface{}
 	if modelsInterface = ctx[rootName]; modelsInterface == nil {
 		return errors.New("expected root document to include a '" + rootName + "' key but it didn't.")
@@ -49,6 +102,13 @@ func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *refl
 		return errors.New("expected slice under key '" + rootName + "'")
 	}
 
+	// Index existing elements by ID once, rather than rescanning the whole
+	// slice for every incoming model.
+	idx, err := buildIDIndex(fm, structType, *sliceVal)
+	if err != nil {
+		return err
+	}
+
 	// Read all the models
 	for _, m := range models {
 		attributes, ok := m.(map[string]interface{})
@@ -66,23 +126,9 @@ func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *refl
 				return errors.New("id must be a string")
 			}
 
-			// If we have an ID, check if there's already an object with that ID in the slice
-			// TODO This is O(n^2), make it O(n)
-			for i := 0; i < sliceVal.Len(); i++ {
-				obj := sliceVal.Index(i)
-				idField := obj.FieldByName("ID")
-				if !idField.IsValid() {
-					return errors.New("expected ID field in struct")
-				}
-				otherID, err := toID(idField)
-				if err != nil {
-					return errors.New("invalid type for ID field")
-				}
-				if otherID == id {
-					val = obj
-					isNew = false
-					break
-				}
+			if i, found := idx[id]; found {
+				val = sliceVal.Index(i)
+				isNew = false
 			}
 		}
 
@@ -94,80 +140,8 @@ func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *refl
 
 		for k, v := range attributes {
 			if k == "links" {
-				linksMap, ok := v.(map[string]interface{})
-				if !ok {
-					return errors.New("expected links to be an object")
-				}
-				for linkName, linkVal := range linksMap {
-					linkList, isASlice := linkVal.([]interface{})
-					// Check for fields named 'FoobarsIDs' for key 'foobars'
-					structFieldName := dejsonify(linkName) + "IDs"
-					field := val.FieldByName(structFieldName)
-					if !field.IsValid() {
-						// no slice, check for single relation
-						structFieldName = dejsonify(linkName) + "ID"
-						field = val.FieldByName(structFieldName)
-					}
-					if !field.IsValid() {
-						return errors.New("expected struct to have a " + structFieldName + " or " + structFieldName + "s field")
-					}
-					var kind reflect.Kind
-					if field.Kind() != reflect.Slice {
-						kind = field.Kind()
-					} else {
-						kind = field.Type().Elem().Kind()
-					}
-					switch kind {
-					case reflect.String:
-						if isASlice {
-							ids := []string{}
-							for _, id := range linkList {
-								idString, ok := id.(string)
-								if !ok {
-									return errors.New("expected " + linkName + " to contain string IDs")
-								}
-								ids = append(ids, idString)
-							}
-							field.Set(reflect.ValueOf(ids))
-						} else {
-							idString, ok := linkVal.(string)
-							if !ok {
-								return errors.New("expected " + linkName + " to contain string IDs")
-							}
-							field.Set(reflect.ValueOf(idString))
-						}
-
-					case reflect.Int:
-						if isASlice {
-							ids := []int{}
-							for _, id := range linkList {
-								idString, ok := id.(string)
-								if !ok {
-									return errors.New("expected " + linkName + " to contain string IDs")
-								}
-								idInt, err := strconv.Atoi(idString)
-								if err != nil {
-									return err
-								}
-								ids = append(ids, idInt)
-							}
-							field.Set(reflect.ValueOf(ids))
-						} else {
-							idString, ok := linkVal.(string)
-							if !ok {
-								return errors.New("expected " + linkName + " to contain string IDs")
-							}
-							idInt, err := strconv.Atoi(idString)
-							if err != nil {
-								return err
-							}
-
-							field.Set(reflect.ValueOf(idInt))
-						}
-
-					default:
-						return errors.New("expected " + structFieldName + " to be a int or string slice")
-					}
+				if err := applyLegacyLinks(structType, val, fm, v); err != nil {
+					return err
 				}
 			} else if k == "id" {
 				// Allow conversion of string id to int
@@ -175,39 +149,507 @@ func unmarshalInto(ctx unmarshalContext, structType reflect.Type, sliceVal *refl
 				if !ok {
 					return errors.New("expected id to be of type string")
 				}
-				field := val.FieldByName("ID")
-				if !field.IsValid() {
-					return errors.New("expected struct " + structType.Name() + " to have field 'ID'")
+				field, err := fm.idFieldOf(structType, val)
+				if err != nil {
+					return err
+				}
+				if err := assignID(field, "ID", id); err != nil {
+					return err
+				}
+			} else {
+				if err := applyLegacyAttribute(structType, val, fm, k, v); err != nil {
+					return err
 				}
-				if field.Kind() == reflect.String {
-					field.Set(reflect.ValueOf(id))
-				} else if field.Kind() == reflect.Int {
-					intID, err := strconv.Atoi(id)
+			}
+		}
+
+		if isNew {
+			*sliceVal = reflect.Append(*sliceVal, val)
+			if id != "" {
+				idx[id] = sliceVal.Len() - 1
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyLegacyLinks copies a pre-1.0 "links" object onto val, used by both
+// the in-memory and streaming legacy code paths.
+func applyLegacyLinks(structType reflect.Type, val reflect.Value, fm *fieldMap, linksInterface interface{}) error {
+	linksMap, ok := linksInterface.(map[string]interface{})
+	if !ok {
+		return errors.New("expected links to be an object")
+	}
+	for linkName, linkVal := range linksMap {
+		linkList, isASlice := linkVal.([]interface{})
+		var field reflect.Value
+		structFieldName := linkName
+		if fm != nil && fm.tagged {
+			taggedField, ok := fm.relationFieldOf(val, linkName)
+			if !ok {
+				return errors.New("expected struct " + structType.Name() + " to have a relation tag for '" + linkName + "'")
+			}
+			field = taggedField
+		} else {
+			// Check for fields named 'FoobarsIDs' for key 'foobars'
+			structFieldName = dejsonify(linkName) + "IDs"
+			field = val.FieldByName(structFieldName)
+			if !field.IsValid() {
+				// no slice, check for single relation
+				structFieldName = dejsonify(linkName) + "ID"
+				field = val.FieldByName(structFieldName)
+			}
+		}
+		if !field.IsValid() {
+			return errors.New("expected struct to have a " + structFieldName + " or " + structFieldName + "s field")
+		}
+		var kind reflect.Kind
+		if field.Kind() != reflect.Slice {
+			kind = field.Kind()
+		} else {
+			kind = field.Type().Elem().Kind()
+		}
+		switch kind {
+		case reflect.String:
+			if isASlice {
+				ids := []string{}
+				for _, id := range linkList {
+					idString, ok := id.(string)
+					if !ok {
+						return errors.New("expected " + linkName + " to contain string IDs")
+					}
+					ids = append(ids, idString)
+				}
+				field.Set(reflect.ValueOf(ids))
+			} else {
+				idString, ok := linkVal.(string)
+				if !ok {
+					return errors.New("expected " + linkName + " to contain string IDs")
+				}
+				field.Set(reflect.ValueOf(idString))
+			}
+
+		case reflect.Int:
+			if isASlice {
+				ids := []int{}
+				for _, id := range linkList {
+					idString, ok := id.(string)
+					if !ok {
+						return errors.New("expected " + linkName + " to contain string IDs")
+					}
+					idInt, err := strconv.Atoi(idString)
 					if err != nil {
 						return err
 					}
-					field.Set(reflect.ValueOf(intID))
-				} else {
-					return errors.New("expected ID to be of type int or string in struct")
+					ids = append(ids, idInt)
 				}
+				field.Set(reflect.ValueOf(ids))
 			} else {
-				fieldName := dejsonify(k)
-				field := val.FieldByName(fieldName)
-				if !field.IsValid() {
-					return errors.New("expected struct " + structType.Name() + " to have field " + fieldName)
+				idString, ok := linkVal.(string)
+				if !ok {
+					return errors.New("expected " + linkName + " to contain string IDs")
 				}
-				field.Set(reflect.ValueOf(v))
+				idInt, err := strconv.Atoi(idString)
+				if err != nil {
+					return err
+				}
+
+				field.Set(reflect.ValueOf(idInt))
 			}
+
+		default:
+			return errors.New("expected " + structFieldName + " to be a int or string slice")
+		}
+	}
+	return nil
+}
+
+// applyLegacyAttribute assigns a single pre-1.0 attribute value onto val,
+// used by both the in-memory and streaming legacy code paths.
+func applyLegacyAttribute(structType reflect.Type, val reflect.Value, fm *fieldMap, k string, v interface{}) error {
+	field, extra, err := fm.attrFieldOf(structType, val, k)
+	if err != nil {
+		return err
+	}
+	// time.Time satisfies encoding/json.Unmarshaler, so the Unix-timestamp
+	// and iso8601 handling in assignTimeAttribute must run before the
+	// generic UnmarshalerJSONAPI/json.Unmarshaler fallback gets a chance to
+	// swallow it.
+	handled, err := assignTimeAttribute(field, v, extra)
+	if err != nil {
+		return err
+	}
+	if !handled {
+		if handled, err = tryCustomUnmarshal(field, v); err != nil {
+			return err
+		}
+	}
+	if !handled {
+		if err := assignAttribute(field, v, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalV1Into reads the JSON API v1.0 "data"/"relationships"/"included"
+// document structure into sliceVal. Resource identifiers inside
+// relationships are {type,id} objects; when a matching resource is present
+// in the top-level "included" array it is hydrated into the corresponding
+// nested struct field, otherwise the bare id is kept if the struct exposes
+// an *ID/*IDs field for it.
+func unmarshalV1Into(ctx unmarshalContext, structType reflect.Type, sliceVal *reflect.Value, fm *fieldMap) error {
+	included, err := indexIncluded(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := ctx["data"]
+	resources, ok := data.([]interface{})
+	if !ok {
+		single, ok := data.(map[string]interface{})
+		if !ok {
+			return errors.New("expected 'data' to be an object or an array of objects")
+		}
+		resources = []interface{}{single}
+	}
+
+	idx, err := buildIDIndex(fm, structType, *sliceVal)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			return errors.New("expected an array of resource objects under key 'data'")
+		}
+
+		val, isNew := findOrCreateByID(structType, sliceVal, resource, idx)
+
+		if err := applyResourceObject(structType, val, resource, included, fm); err != nil {
+			return err
 		}
 
 		if isNew {
 			*sliceVal = reflect.Append(*sliceVal, val)
+			if id, _ := resource["id"].(string); id != "" {
+				idx[id] = sliceVal.Len() - 1
+			}
 		}
 	}
 
 	return nil
 }
 
+// includedKey identifies a sideloaded resource by its (type, id) pair.
+type includedKey struct {
+	Type string
+	ID   string
+}
+
+// indexIncluded builds a (type,id) -> resource object lookup out of the
+// top-level "included" array so relationships can be resolved in O(1).
+func indexIncluded(ctx unmarshalContext) (map[includedKey]map[string]interface{}, error) {
+	index := map[includedKey]map[string]interface{}{}
+
+	includedInterface, ok := ctx["included"]
+	if !ok || includedInterface == nil {
+		return index, nil
+	}
+	includedList, ok := includedInterface.([]interface{})
+	if !ok {
+		return nil, errors.New("expected 'included' to be an array of resource objects")
+	}
+
+	for _, i := range includedList {
+		resource, ok := i.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("expected an array of resource objects under key 'included'")
+		}
+		resourceType, id, err := typeAndID(resource)
+		if err != nil {
+			return nil, err
+		}
+		index[includedKey{Type: resourceType, ID: id}] = resource
+	}
+
+	return index, nil
+}
+
+func typeAndID(resource map[string]interface{}) (string, string, error) {
+	resourceType, ok := resource["type"].(string)
+	if !ok {
+		return "", "", errors.New("expected resource object to have a string 'type'")
+	}
+	id, ok := resource["id"].(string)
+	if !ok {
+		return "", "", errors.New("expected resource object to have a string 'id'")
+	}
+	return resourceType, id, nil
+}
+
+// identifierTypeAndID is typeAndID for a relationship data entry whose
+// shape hasn't been checked yet, guarding against a malformed payload
+// (e.g. a bare string instead of a {type,id} object) with a descriptive
+// error instead of a panicking type assertion.
+func identifierTypeAndID(identifierInterface interface{}) (string, string, error) {
+	identifier, ok := identifierInterface.(map[string]interface{})
+	if !ok {
+		return "", "", errors.New("expected a resource identifier object")
+	}
+	return typeAndID(identifier)
+}
+
+// findOrCreateByID looks up an existing element of sliceVal by ID in idx,
+// returning a fresh zero value when none is found.
+func findOrCreateByID(structType reflect.Type, sliceVal *reflect.Value, resource map[string]interface{}, idx map[string]int) (reflect.Value, bool) {
+	if id, _ := resource["id"].(string); id != "" {
+		if i, found := idx[id]; found {
+			return sliceVal.Index(i), false
+		}
+	}
+
+	return reflect.New(structType).Elem(), true
+}
+
+// applyResourceObject copies a v1.0 resource object's id, attributes and
+// relationships onto val.
+func applyResourceObject(structType reflect.Type, val reflect.Value, resource map[string]interface{}, included map[includedKey]map[string]interface{}, fm *fieldMap) error {
+	if id, ok := resource["id"]; ok {
+		idStr, ok := id.(string)
+		if !ok {
+			return errors.New("expected id to be of type string")
+		}
+		field, err := fm.idFieldOf(structType, val)
+		if err != nil {
+			return err
+		}
+		if err := assignID(field, "ID", idStr); err != nil {
+			return err
+		}
+	}
+
+	if attributesInterface, ok := resource["attributes"]; ok && attributesInterface != nil {
+		attributes, ok := attributesInterface.(map[string]interface{})
+		if !ok {
+			return errors.New("expected 'attributes' to be an object")
+		}
+		for k, v := range attributes {
+			if err := applyLegacyAttribute(structType, val, fm, k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if relationshipsInterface, ok := resource["relationships"]; ok && relationshipsInterface != nil {
+		relationships, ok := relationshipsInterface.(map[string]interface{})
+		if !ok {
+			return errors.New("expected 'relationships' to be an object")
+		}
+		for name, relInterface := range relationships {
+			if err := applyRelationship(structType, val, name, relInterface, included, fm); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyRelationship resolves a single relationship entry, hydrating any
+// sideloaded resources it references into the corresponding struct field,
+// and falling back to bare id(s) when the struct only exposes a FooID(s)
+// field or the reference isn't present in "included".
+func applyRelationship(structType reflect.Type, val reflect.Value, name string, relInterface interface{}, included map[includedKey]map[string]interface{}, fm *fieldMap) error {
+	rel, ok := relInterface.(map[string]interface{})
+	if !ok {
+		return errors.New("expected relationship '" + name + "' to be an object")
+	}
+	relData := rel["data"]
+
+	if fm != nil && fm.tagged {
+		field, ok := fm.relationFieldOf(val, name)
+		if !ok {
+			return errors.New("expected struct " + structType.Name() + " to have a relation tag for '" + name + "'")
+		}
+		return hydrateRelationshipField(field, relData, included)
+	}
+
+	fieldName := dejsonify(name)
+	if field := val.FieldByName(fieldName); field.IsValid() {
+		return hydrateRelationshipField(field, relData, included)
+	}
+
+	return applyRelationshipIDs(val, name, relData)
+}
+
+// hydrateRelationshipField fills a nested struct (or slice of structs)
+// relationship field from the sideloaded resources referenced by relData,
+// falling back to just the ID when a reference isn't sideloaded.
+func hydrateRelationshipField(field reflect.Value, relData interface{}, included map[includedKey]map[string]interface{}) error {
+	if relData == nil {
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice {
+		identifiers, ok := relData.([]interface{})
+		if !ok {
+			return errors.New("expected relationship data to be an array of resource identifiers")
+		}
+		elemType := field.Type().Elem()
+		items := reflect.MakeSlice(field.Type(), 0, len(identifiers))
+		for _, idf := range identifiers {
+			item, err := hydrateIdentifier(elemType, idf, included)
+			if err != nil {
+				return err
+			}
+			items = reflect.Append(items, item)
+		}
+		field.Set(items)
+		return nil
+	}
+
+	item, err := hydrateIdentifier(field.Type(), relData, included)
+	if err != nil {
+		return err
+	}
+	field.Set(item)
+	return nil
+}
+
+func hydrateIdentifier(elemType reflect.Type, identifierInterface interface{}, included map[includedKey]map[string]interface{}) (reflect.Value, error) {
+	identifier, ok := identifierInterface.(map[string]interface{})
+	if !ok {
+		return reflect.Value{}, errors.New("expected a resource identifier object")
+	}
+	resourceType, id, err := typeAndID(identifier)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	item := reflect.New(elemType).Elem()
+
+	// Relation fields aren't always nested structs: a tagged relation may
+	// just be a plain int/string id (or slice thereof).
+	if elemType.Kind() != reflect.Struct {
+		switch elemType.Kind() {
+		case reflect.String:
+			item.SetString(id)
+		case reflect.Int:
+			intID, err := strconv.Atoi(id)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			item.SetInt(int64(intID))
+		}
+		return item, nil
+	}
+
+	elemFm := buildFieldMap(elemType)
+	if resource, ok := included[includedKey{Type: resourceType, ID: id}]; ok {
+		if err := applyResourceObject(elemType, item, resource, included, elemFm); err != nil {
+			return reflect.Value{}, err
+		}
+		return item, nil
+	}
+
+	// Not sideloaded: populate just the ID field so callers can still fetch
+	// the resource out-of-band.
+	if idField, err := elemFm.idFieldOf(elemType, item); err == nil {
+		// Best-effort: a malformed id here shouldn't fail the whole
+		// unmarshal since the resource wasn't sideloaded anyway.
+		_ = assignID(idField, "ID", id)
+	}
+	return item, nil
+}
+
+// applyRelationshipIDs is the pre-1.0-style fallback for relationships that
+// don't have a matching nested struct field: store into FoobarIDs/FoobarID.
+func applyRelationshipIDs(val reflect.Value, name string, relData interface{}) error {
+	if relData == nil {
+		return nil
+	}
+
+	structFieldName := dejsonify(name) + "IDs"
+	field := val.FieldByName(structFieldName)
+	isASlice := true
+	if !field.IsValid() {
+		structFieldName = dejsonify(name) + "ID"
+		field = val.FieldByName(structFieldName)
+		isASlice = false
+	}
+	if !field.IsValid() {
+		return errors.New("expected struct to have a " + structFieldName + " or " + structFieldName + "s field")
+	}
+
+	var kind reflect.Kind
+	if field.Kind() != reflect.Slice {
+		kind = field.Kind()
+	} else {
+		kind = field.Type().Elem().Kind()
+	}
+
+	if isASlice {
+		identifiers, ok := relData.([]interface{})
+		if !ok {
+			return errors.New("expected relationship '" + name + "' data to be an array of resource identifiers")
+		}
+		switch kind {
+		case reflect.String:
+			ids := []string{}
+			for _, idf := range identifiers {
+				_, id, err := identifierTypeAndID(idf)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, id)
+			}
+			field.Set(reflect.ValueOf(ids))
+		case reflect.Int:
+			ids := []int{}
+			for _, idf := range identifiers {
+				_, id, err := identifierTypeAndID(idf)
+				if err != nil {
+					return err
+				}
+				idInt, err := strconv.Atoi(id)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, idInt)
+			}
+			field.Set(reflect.ValueOf(ids))
+		default:
+			return errors.New("expected " + structFieldName + " to be a int or string slice")
+		}
+		return nil
+	}
+
+	identifier, ok := relData.(map[string]interface{})
+	if !ok {
+		return errors.New("expected relationship '" + name + "' data to be a resource identifier object")
+	}
+	_, id, err := typeAndID(identifier)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case reflect.String:
+		field.Set(reflect.ValueOf(id))
+	case reflect.Int:
+		idInt, err := strconv.Atoi(id)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(idInt))
+	default:
+		return errors.New("expected " + structFieldName + " to be a int or string")
+	}
+	return nil
+}
+
 // UnmarshalJSON reads a JSONAPI compatible JSON document to a model struct
 func UnmarshalJSON(data []byte, values interface{}) error {
 	var ctx unmarshalContext
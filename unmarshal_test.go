@@ -0,0 +1,127 @@
+package api2go
+
+import (
+	"testing"
+	"time"
+)
+
+// timeEvent exercises chunk0-3 (time.Time coercion) together with
+// chunk0-6 (UnmarshalerJSONAPI/json.Unmarshaler escape hatch): time.Time
+// satisfies json.Unmarshaler, so the Unix-timestamp/ISO-8601 handling must
+// run before the generic custom-unmarshal fallback gets a chance to treat
+// it as a plain json.Unmarshaler and fail on the non-string input.
+type timeEvent struct {
+	ID string    `api2go:"primary,events"`
+	At time.Time `api2go:"attr,at"`
+}
+
+type isoEvent struct {
+	ID string    `api2go:"primary,events"`
+	At time.Time `api2go:"attr,at,iso8601"`
+}
+
+func TestUnmarshalTimeAttributeUnixTimestamp(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "events",
+			"id":         "1",
+			"attributes": map[string]interface{}{"at": float64(1690000000)},
+		},
+	}
+
+	var e timeEvent
+	if err := UnmarshalOne(ctx, &e); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if want := time.Unix(1690000000, 0); !e.At.Equal(want) {
+		t.Fatalf("At = %v, want %v", e.At, want)
+	}
+}
+
+func TestUnmarshalTimeAttributeRFC3339(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "events",
+			"id":         "1",
+			"attributes": map[string]interface{}{"at": "2023-07-22T04:26:40Z"},
+		},
+	}
+
+	var e timeEvent
+	if err := UnmarshalOne(ctx, &e); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if want := time.Unix(1690000000, 0).UTC(); !e.At.Equal(want) {
+		t.Fatalf("At = %v, want %v", e.At, want)
+	}
+}
+
+func TestUnmarshalISO8601RejectsNumericInput(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "events",
+			"id":         "1",
+			"attributes": map[string]interface{}{"at": float64(1690000000)},
+		},
+	}
+
+	var e isoEvent
+	err := UnmarshalOne(ctx, &e)
+	if err != ErrInvalidISO8601 {
+		t.Fatalf("err = %v, want ErrInvalidISO8601", err)
+	}
+}
+
+// widget exercises chunk0-2's claim that tagged fields may be unexported.
+type widget struct {
+	id   string `api2go:"primary,widgets"`
+	Name string `api2go:"attr,name"`
+}
+
+func TestUnmarshalUnexportedTaggedPrimaryField(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "widgets",
+			"id":         "42",
+			"attributes": map[string]interface{}{"name": "gizmo"},
+		},
+	}
+
+	var w widget
+	if err := UnmarshalOne(ctx, &w); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if w.id != "42" {
+		t.Fatalf("id = %q, want %q", w.id, "42")
+	}
+	if w.Name != "gizmo" {
+		t.Fatalf("Name = %q, want %q", w.Name, "gizmo")
+	}
+}
+
+// legacyPost has no api2go tags, so relationship resolution falls back to
+// the pre-1.0 FooIDs/FooID naming convention exercised here.
+type legacyPost struct {
+	ID         string
+	CommentIDs []string
+}
+
+func TestUnmarshalMalformedRelationshipDataReturnsError(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type": "legacyPosts",
+			"id":   "1",
+			"relationships": map[string]interface{}{
+				"comment": map[string]interface{}{
+					"data": []interface{}{"not-a-resource-identifier"},
+				},
+			},
+		},
+	}
+
+	var p legacyPost
+	err := UnmarshalOne(ctx, &p)
+	if err == nil {
+		t.Fatal("expected an error for malformed relationship data, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+package api2go
+
+import "testing"
+
+// author and post exercise chunk0-1's core "data"/"relationships"/"included"
+// hydration path: a sideloaded relationship resolves into the nested
+// struct, while an un-sideloaded one falls back to a bare id.
+type author struct {
+	ID   string `api2go:"primary,authors"`
+	Name string `api2go:"attr,name"`
+}
+
+type post struct {
+	ID     string `api2go:"primary,posts"`
+	Title  string `api2go:"attr,title"`
+	Author author `api2go:"relation,author"`
+}
+
+func TestUnmarshalV1HydratesSideloadedRelationship(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "posts",
+			"id":         "1",
+			"attributes": map[string]interface{}{"title": "hello"},
+			"relationships": map[string]interface{}{
+				"author": map[string]interface{}{
+					"data": map[string]interface{}{"type": "authors", "id": "7"},
+				},
+			},
+		},
+		"included": []interface{}{
+			map[string]interface{}{
+				"type":       "authors",
+				"id":         "7",
+				"attributes": map[string]interface{}{"name": "Ada"},
+			},
+		},
+	}
+
+	var p post
+	if err := UnmarshalOne(ctx, &p); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if p.Title != "hello" {
+		t.Fatalf("Title = %q, want %q", p.Title, "hello")
+	}
+	if p.Author.ID != "7" || p.Author.Name != "Ada" {
+		t.Fatalf("Author = %+v, want ID 7 and Name Ada", p.Author)
+	}
+}
+
+func TestUnmarshalV1FallsBackToBareIDWhenNotSideloaded(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type":       "posts",
+			"id":         "1",
+			"attributes": map[string]interface{}{"title": "hello"},
+			"relationships": map[string]interface{}{
+				"author": map[string]interface{}{
+					"data": map[string]interface{}{"type": "authors", "id": "7"},
+				},
+			},
+		},
+	}
+
+	var p post
+	if err := UnmarshalOne(ctx, &p); err != nil {
+		t.Fatalf("UnmarshalOne: %v", err)
+	}
+	if p.Author.ID != "7" {
+		t.Fatalf("Author.ID = %q, want %q (bare id fallback)", p.Author.ID, "7")
+	}
+	if p.Author.Name != "" {
+		t.Fatalf("Author.Name = %q, want empty (not sideloaded)", p.Author.Name)
+	}
+}
+
+// tag is a tagged relation whose id is a plain int rather than a nested
+// struct, exercising hydrateIdentifier's non-struct branch directly.
+type tag struct {
+	ID   string `api2go:"primary,comments"`
+	Tags []int  `api2go:"relation,tags"`
+}
+
+func TestUnmarshalV1RelationIntIDRejectsNonNumeric(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": map[string]interface{}{
+			"type": "comments",
+			"id":   "1",
+			"relationships": map[string]interface{}{
+				"tags": map[string]interface{}{
+					"data": []interface{}{
+						map[string]interface{}{"type": "tags", "id": "not-a-number"},
+					},
+				},
+			},
+		},
+	}
+
+	var c tag
+	if err := UnmarshalOne(ctx, &c); err == nil {
+		t.Fatal("expected an error for a non-numeric id into an []int relation field, got nil")
+	}
+}
+
+func TestUnmarshalV1MergesByIDAcrossMultipleResources(t *testing.T) {
+	ctx := unmarshalContext{
+		"data": []interface{}{
+			map[string]interface{}{
+				"type":       "posts",
+				"id":         "1",
+				"attributes": map[string]interface{}{"title": "first"},
+			},
+			map[string]interface{}{
+				"type":       "posts",
+				"id":         "1",
+				"attributes": map[string]interface{}{"title": "updated"},
+			},
+		},
+	}
+
+	var posts []post
+	if err := UnmarshalMany(ctx, &posts); err != nil {
+		t.Fatalf("UnmarshalMany: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("len(posts) = %d, want 1 (same id should merge, not duplicate)", len(posts))
+	}
+	if posts[0].Title != "updated" {
+		t.Fatalf("Title = %q, want %q", posts[0].Title, "updated")
+	}
+}